@@ -0,0 +1,195 @@
+package lambroll
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdav2 "github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdav2types "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// PruneOption represents option for Prune()
+type PruneOption struct {
+	FunctionFilePath *string
+	KeepVersions     *int
+	KeepSince        *time.Duration
+	DryRun           *bool
+	// Filter, if set, is called for each version not already kept by
+	// KeepVersions or an alias (before KeepSince is checked). Return true if the version is
+	// eligible for deletion; returning false preserves it, same as the
+	// other keep conditions.
+	Filter func(lambdav2types.FunctionConfiguration) bool
+}
+
+func (opt PruneOption) label() string {
+	if opt.DryRun != nil && *opt.DryRun {
+		return "**DRY RUN**"
+	}
+	return ""
+}
+
+// Prune deletes old, unaliased versions of the function to reclaim storage.
+func (app *App) Prune(opt PruneOption) error {
+	ctx := context.TODO()
+	fn, err := app.loadFunctionV2(*opt.FunctionFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load function: %w", err)
+	}
+	functionName := *fn.FunctionName
+
+	log.Printf("[info] starting prune function %s %s", functionName, opt.label())
+
+	aliased, err := app.aliasedVersions(ctx, functionName)
+	if err != nil {
+		return err
+	}
+
+	versions, err := app.listFunctionVersions(ctx, functionName)
+	if err != nil {
+		return err
+	}
+
+	dryRun := opt.DryRun != nil && *opt.DryRun
+	for _, version := range selectVersionsToPrune(versions, aliased, opt, time.Now()) {
+		if dryRun {
+			log.Printf("[info] %s would delete version %s", opt.label(), version)
+			continue
+		}
+		log.Printf("[info] deleting version %s", version)
+		_, err := app.lambdav2.DeleteFunction(ctx, &lambdav2.DeleteFunctionInput{
+			FunctionName: aws.String(functionName),
+			Qualifier:    aws.String(version),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete version: %w", err)
+		}
+	}
+	return nil
+}
+
+// selectVersionsToPrune applies the KeepVersions/KeepSince/alias/Filter
+// precedence to versions and returns the version numbers (oldest first)
+// that are eligible for deletion. versions is sorted numerically before
+// KeepVersions is applied, since ListVersionsByFunction's page order is
+// not a contract lambroll can rely on.
+func selectVersionsToPrune(versions []lambdav2types.FunctionConfiguration, aliased map[string]bool, opt PruneOption, now time.Time) []string {
+	sorted := sortVersionsNumeric(versions)
+
+	keep := 0
+	if opt.KeepVersions != nil {
+		keep = *opt.KeepVersions
+	}
+	cutoff := len(sorted) - keep
+	if cutoff < 0 {
+		cutoff = 0
+	}
+
+	var since time.Time
+	if opt.KeepSince != nil {
+		since = now.Add(-*opt.KeepSince)
+	}
+
+	var result []string
+	for i, v := range sorted {
+		version := aws.ToString(v.Version)
+		if i >= cutoff {
+			log.Printf("[debug] version %s is within the most recent %d versions, keep", version, keep)
+			continue
+		}
+		if aliased[version] {
+			log.Printf("[debug] version %s is still aliased, keep", version)
+			continue
+		}
+		if opt.Filter != nil && !opt.Filter(v) {
+			log.Printf("[debug] version %s filtered out, keep", version)
+			continue
+		}
+		if !since.IsZero() {
+			lm, err := parseLastModified(aws.ToString(v.LastModified))
+			if err != nil {
+				log.Printf("[debug] version %s has unparseable LastModified %q, keep", version, aws.ToString(v.LastModified))
+				continue
+			}
+			if lm.After(since) {
+				log.Printf("[debug] version %s is newer than keep-since, keep", version)
+				continue
+			}
+		}
+		result = append(result, version)
+	}
+	return result
+}
+
+// lastModifiedLayouts are the timestamp layouts FunctionConfiguration.LastModified
+// has been observed to use. The Lambda API documents RFC 3339, but in practice
+// returns a non-RFC-3339 offset without a colon (e.g. "2019-09-23T18:32:33.857+0000"),
+// which time.RFC3339 fails to parse.
+var lastModifiedLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	time.RFC3339,
+}
+
+// parseLastModified parses a FunctionConfiguration.LastModified value, trying
+// each known layout in turn.
+func parseLastModified(s string) (time.Time, error) {
+	var lastErr error
+	for _, layout := range lastModifiedLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, fmt.Errorf("failed to parse LastModified %q: %w", s, lastErr)
+}
+
+// listFunctionVersions returns the published (non-$LATEST) versions of
+// functionName, in whatever page order the Lambda API returns them.
+func (app *App) listFunctionVersions(ctx context.Context, functionName string) ([]lambdav2types.FunctionConfiguration, error) {
+	var versions []lambdav2types.FunctionConfiguration
+	p := lambdav2.NewListVersionsByFunctionPaginator(app.lambdav2, &lambdav2.ListVersionsByFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions: %w", err)
+		}
+		for _, v := range page.Versions {
+			if *v.Version == "$LATEST" {
+				continue
+			}
+			versions = append(versions, v)
+		}
+	}
+	return versions, nil
+}
+
+// aliasedVersions returns the set of versions of functionName that are still
+// referenced by at least one alias.
+func (app *App) aliasedVersions(ctx context.Context, functionName string) (map[string]bool, error) {
+	aliased := map[string]bool{}
+	p := lambdav2.NewListAliasesPaginator(app.lambdav2, &lambdav2.ListAliasesInput{
+		FunctionName: aws.String(functionName),
+	})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list aliases: %w", err)
+		}
+		for _, a := range page.Aliases {
+			if a.FunctionVersion != nil {
+				aliased[*a.FunctionVersion] = true
+			}
+			if a.RoutingConfig != nil {
+				for v := range a.RoutingConfig.AdditionalVersionWeights {
+					aliased[v] = true
+				}
+			}
+		}
+	}
+	return aliased, nil
+}
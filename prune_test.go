@@ -0,0 +1,110 @@
+package lambroll
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	lambdav2types "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+func fnConfig(version, lastModified string) lambdav2types.FunctionConfiguration {
+	return lambdav2types.FunctionConfiguration{
+		Version:      &version,
+		LastModified: &lastModified,
+	}
+}
+
+// lambdaTimeLayout matches the non-RFC-3339 layout the Lambda API actually
+// returns for FunctionConfiguration.LastModified (no colon in the offset),
+// e.g. "2019-09-23T18:32:33.857+0000".
+const lambdaTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+func TestSelectVersionsToPrune(t *testing.T) {
+	now := time.Date(2026, 7, 28, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour).Format(lambdaTimeLayout)
+	recent := now.Add(-1 * time.Hour).Format(lambdaTimeLayout)
+
+	// deliberately out of numeric order, as a page from ListVersionsByFunction
+	// is not guaranteed to be sorted.
+	versions := []lambdav2types.FunctionConfiguration{
+		fnConfig("10", old),
+		fnConfig("2", old),
+		fnConfig("1", old),
+		fnConfig("9", old),
+		fnConfig("3", recent),
+	}
+
+	cases := []struct {
+		name    string
+		aliased map[string]bool
+		opt     PruneOption
+		want    []string
+	}{
+		{
+			name: "keep versions preserves most recent N numerically",
+			opt:  PruneOption{KeepVersions: intp(2)},
+			want: []string{"1", "2", "3"},
+		},
+		{
+			name:    "aliased versions are always kept",
+			aliased: map[string]bool{"2": true},
+			opt:     PruneOption{KeepVersions: intp(1)},
+			want:    []string{"1", "3", "9"},
+		},
+		{
+			name: "keep since preserves versions newer than the cutoff",
+			opt:  PruneOption{KeepSince: durp(24 * time.Hour)},
+			want: []string{"1", "2", "9", "10"},
+		},
+		{
+			name: "filter true means delete-eligible",
+			opt: PruneOption{Filter: func(v lambdav2types.FunctionConfiguration) bool {
+				return *v.Version != "9"
+			}},
+			want: []string{"1", "2", "3", "10"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := selectVersionsToPrune(versions, c.aliased, c.opt, now)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("selectVersionsToPrune() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSelectVersionsToPruneLambdaTimestampFormat(t *testing.T) {
+	now := time.Date(2026, 7, 28, 0, 0, 0, 0, time.UTC)
+
+	versions := []lambdav2types.FunctionConfiguration{
+		// a literal Lambda-style timestamp, not round-tripped through time.Format,
+		// to guard against reverting to an RFC-3339-only parser.
+		fnConfig("1", "2026-07-27T12:00:00.000+0000"),
+		fnConfig("2", "2026-07-01T00:00:00.000+0000"),
+	}
+
+	got := selectVersionsToPrune(versions, nil, PruneOption{KeepSince: durp(24 * time.Hour)}, now)
+	want := []string{"2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("selectVersionsToPrune() = %v, want %v (recent Lambda-formatted version must be kept, not pruned)", got, want)
+	}
+}
+
+func TestSelectVersionsToPruneKeepsUnparseableTimestamp(t *testing.T) {
+	now := time.Date(2026, 7, 28, 0, 0, 0, 0, time.UTC)
+
+	versions := []lambdav2types.FunctionConfiguration{
+		fnConfig("1", "not-a-timestamp"),
+	}
+
+	got := selectVersionsToPrune(versions, nil, PruneOption{KeepSince: durp(24 * time.Hour)}, now)
+	if len(got) != 0 {
+		t.Fatalf("selectVersionsToPrune() = %v, want no versions deleted for an unparseable LastModified", got)
+	}
+}
+
+func intp(i int) *int                     { return &i }
+func durp(d time.Duration) *time.Duration { return &d }
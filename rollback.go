@@ -1,11 +1,14 @@
 package lambroll
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -18,6 +21,19 @@ type RollbackOption struct {
 	FunctionFilePath *string
 	DryRun           *bool
 	DeleteVersion    *bool
+	TargetVersion    *string
+	StepsBack        *int
+	AliasName        *string
+	Weight           *float64
+	HoldFor          *time.Duration
+	Interactive      *bool
+}
+
+func (opt RollbackOption) aliasName() string {
+	if opt.AliasName != nil {
+		return *opt.AliasName
+	}
+	return CurrentAliasName
 }
 
 func (opt RollbackOption) label() string {
@@ -35,11 +51,24 @@ func (app *App) Rollback(opt RollbackOption) error {
 		return fmt.Errorf("failed to load function: %w", err)
 	}
 
-	log.Printf("[info] starting rollback function %s", *fn.FunctionName)
+	if opt.Weight != nil && opt.HoldFor == nil && opt.DeleteVersion != nil && *opt.DeleteVersion {
+		return errors.New("--delete-version cannot be used with --weight unless --hold-for is also set, since the alias is left split between versions")
+	}
+
+	aliasName := opt.aliasName()
+	log.Printf("[info] starting rollback function %s alias %s", *fn.FunctionName, aliasName)
+
+	if opt.Interactive != nil && *opt.Interactive {
+		tv, err := app.chooseVersionInteractively(*opt.FunctionFilePath)
+		if err != nil {
+			return err
+		}
+		opt.TargetVersion = &tv
+	}
 
 	res, err := app.lambdav2.GetAlias(ctx, &lambdav2.GetAliasInput{
 		FunctionName: fn.FunctionName,
-		Name:         aws.String(CurrentAliasName),
+		Name:         aws.String(aliasName),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to get alias: %w", err)
@@ -52,64 +81,175 @@ func (app *App) Rollback(opt RollbackOption) error {
 	}
 
 	var prevVersion string
-VERSIONS:
-	for v := cv - 1; v > 0; v-- {
-		log.Printf("[debug] get function version %d", v)
-		vs := strconv.FormatInt(v, 10)
+	switch {
+	case opt.TargetVersion != nil:
+		tv := *opt.TargetVersion
+		if tv == "$LATEST" {
+			return errors.New("cannot rollback to $LATEST")
+		}
 		res, err := app.lambdav2.GetFunction(ctx, &lambdav2.GetFunctionInput{
 			FunctionName: fn.FunctionName,
-			Qualifier:    aws.String(vs),
+			Qualifier:    aws.String(tv),
 		})
 		if err != nil {
 			var nfe *lambdav2types.ResourceNotFoundException
 			if errors.As(err, &nfe) {
-				log.Printf("[debug] version %s not found", vs)
-				continue VERSIONS
-			} else {
-				return fmt.Errorf("failed to get function: %w", err)
+				return fmt.Errorf("version %s not found", tv)
 			}
+			return fmt.Errorf("failed to get function: %w", err)
 		}
 		prevVersion = *res.Configuration.Version
-		break
-	}
-	if prevVersion == "" {
-		return errors.New("unable to detect previous version of function")
+	case opt.StepsBack != nil:
+		steps := *opt.StepsBack
+		if steps < 1 {
+			return fmt.Errorf("StepsBack must be 1 or more")
+		}
+		prevVersion, err = app.findPreviousVersion(ctx, fn.FunctionName, cv, steps)
+		if err != nil {
+			return err
+		}
+	default:
+		prevVersion, err = app.findPreviousVersion(ctx, fn.FunctionName, cv, 1)
+		if err != nil {
+			return err
+		}
 	}
 
 	log.Printf("[info] rollbacking function version %s to %s %s", currentVersion, prevVersion, opt.label())
 	if *opt.DryRun {
 		return nil
 	}
-	err = app.updateAliases(*fn.FunctionName, versionAlias{Version: prevVersion, Name: CurrentAliasName})
-	if err != nil {
-		return err
+
+	if opt.Weight != nil {
+		if err := app.rollbackWithWeight(ctx, *fn.FunctionName, aliasName, currentVersion, prevVersion, *opt.Weight, opt.HoldFor, opt.label()); err != nil {
+			return err
+		}
+	} else {
+		err = app.updateAliases(*fn.FunctionName, versionAlias{Version: prevVersion, Name: aliasName})
+		if err != nil {
+			return err
+		}
 	}
 
 	if !*opt.DeleteVersion {
 		return nil
 	}
 
-	return app.deleteFunctionVersion(*fn.FunctionName, currentVersion)
+	return app.deleteFunctionVersion(*fn.FunctionName, aliasName, currentVersion)
 }
 
-func (app *App) deleteFunctionVersion(functionName, version string) error {
+// chooseVersionInteractively prints the versions table for functionFilePath
+// and prompts the user on stdin to choose one to roll back to, mirroring
+// `helm history` followed by `helm rollback REVISION`.
+func (app *App) chooseVersionInteractively(functionFilePath string) (string, error) {
+	if _, err := app.Versions(VersionsOption{FunctionFilePath: &functionFilePath}); err != nil {
+		return "", fmt.Errorf("failed to list versions: %w", err)
+	}
+	fmt.Fprint(os.Stdout, "Enter a version to rollback to: ")
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return "", errors.New("no version selected")
+	}
+	version := strings.TrimSpace(scanner.Text())
+	if version == "" {
+		return "", errors.New("no version selected")
+	}
+	return version, nil
+}
+
+// findPreviousVersion scans backward from (but not including) version `from`,
+// skipping versions that no longer exist (ResourceNotFoundException), and
+// returns the version found after `steps` valid versions are encountered.
+func (app *App) findPreviousVersion(ctx context.Context, functionName *string, from int64, steps int) (string, error) {
+	return findPreviousVersionUsing(from, steps, func(v int64) (bool, error) {
+		log.Printf("[debug] get function version %d", v)
+		vs := strconv.FormatInt(v, 10)
+		_, err := app.lambdav2.GetFunction(ctx, &lambdav2.GetFunctionInput{
+			FunctionName: functionName,
+			Qualifier:    aws.String(vs),
+		})
+		if err != nil {
+			var nfe *lambdav2types.ResourceNotFoundException
+			if errors.As(err, &nfe) {
+				log.Printf("[debug] version %s not found", vs)
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get function: %w", err)
+		}
+		return true, nil
+	})
+}
+
+// findPreviousVersionUsing holds the gap-skipping scan logic behind a plain
+// existence callback so it can be unit tested without a Lambda client: it
+// scans backward from (but not including) from, skipping versions for which
+// exists returns false, and returns the version found after `steps` such
+// versions are encountered.
+func findPreviousVersionUsing(from int64, steps int, exists func(v int64) (bool, error)) (string, error) {
+	var version string
+	v := from - 1
+	for i := 0; i < steps; i++ {
+		found := false
+		for ; v > 0; v-- {
+			ok, err := exists(v)
+			if err != nil {
+				return "", err
+			}
+			if !ok {
+				continue
+			}
+			version = strconv.FormatInt(v, 10)
+			v--
+			found = true
+			break
+		}
+		if !found {
+			return "", errors.New("unable to detect previous version of function")
+		}
+	}
+	return version, nil
+}
+
+// deleteVersionConsistencyRetries bounds how many times deleteFunctionVersion
+// waits out eventual consistency of the alias it just moved off of version,
+// before giving up instead of retrying forever.
+const deleteVersionConsistencyRetries = 10
+
+// deleteFunctionVersion deletes version of functionName, after confirming it
+// is safe to do so. aliasName is the alias that was just moved off version;
+// GetAlias for it may still reflect the old version for a short time due to
+// eventual consistency, so that alone is retried a bounded number of times.
+// If any *other* alias still references version, that's not a consistency
+// blip, so it's reported as an error instead of retried indefinitely.
+func (app *App) deleteFunctionVersion(functionName, aliasName, version string) error {
 	ctx := context.TODO()
-	for {
+	for i := 0; ; i++ {
 		log.Printf("[debug] checking aliased version")
 		res, err := app.lambdav2.GetAlias(ctx, &lambdav2.GetAliasInput{
 			FunctionName: aws.String(functionName),
-			Name:         aws.String(CurrentAliasName),
+			Name:         aws.String(aliasName),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to get alias: %w", err)
 		}
-		if *res.FunctionVersion == version {
-			log.Printf("[debug] version %s still has alias %s, retrying", version, CurrentAliasName)
-			time.Sleep(time.Second)
-			continue
+		if aws.ToString(res.FunctionVersion) != version {
+			break
 		}
-		break
+		if i >= deleteVersionConsistencyRetries {
+			return fmt.Errorf("alias %s still points at version %s after %d retries, refusing to delete", aliasName, version, deleteVersionConsistencyRetries)
+		}
+		log.Printf("[debug] alias %s still reflects version %s, retrying", aliasName, version)
+		time.Sleep(time.Second)
 	}
+
+	aliased, aliasErr := app.isVersionAliased(ctx, functionName, version)
+	if aliasErr != nil {
+		return aliasErr
+	}
+	if aliased {
+		return fmt.Errorf("version %s is still referenced by another alias, refusing to delete", version)
+	}
+
 	log.Printf("[info] deleting function version %s", version)
 	_, err := app.lambdav2.DeleteFunction(ctx, &lambdav2.DeleteFunctionInput{
 		FunctionName: aws.String(functionName),
@@ -120,3 +260,58 @@ func (app *App) deleteFunctionVersion(functionName, version string) error {
 	}
 	return nil
 }
+
+// rollbackWithWeight performs a gradual rollback by shifting weight of traffic
+// on aliasName from currentVersion to prevVersion via RoutingConfig.AdditionalVersionWeights,
+// instead of an immediate hard cutover. If holdFor is set, it sleeps and then
+// promotes prevVersion to the alias outright, clearing the routing config.
+func (app *App) rollbackWithWeight(ctx context.Context, functionName, aliasName, currentVersion, prevVersion string, weight float64, holdFor *time.Duration, label string) error {
+	if weight <= 0 || weight >= 1 {
+		return fmt.Errorf("Weight must be greater than 0.0 and less than 1.0")
+	}
+	log.Printf("[info] shifting %.0f%% of traffic on alias %s from %s to %s %s", weight*100, aliasName, currentVersion, prevVersion, label)
+	_, err := app.lambdav2.UpdateAlias(ctx, &lambdav2.UpdateAliasInput{
+		FunctionName:    aws.String(functionName),
+		Name:            aws.String(aliasName),
+		FunctionVersion: aws.String(currentVersion),
+		RoutingConfig: &lambdav2types.AliasRoutingConfiguration{
+			AdditionalVersionWeights: map[string]float64{prevVersion: weight},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update alias: %w", err)
+	}
+
+	if holdFor == nil {
+		return nil
+	}
+	log.Printf("[info] holding for %s before promoting version %s on alias %s", *holdFor, prevVersion, aliasName)
+	timer := time.NewTimer(*holdFor)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("canceled while holding rollback weight on alias %s: %w", aliasName, ctx.Err())
+	case <-timer.C:
+	}
+
+	log.Printf("[info] promoting version %s to alias %s %s", prevVersion, aliasName, label)
+	_, err = app.lambdav2.UpdateAlias(ctx, &lambdav2.UpdateAliasInput{
+		FunctionName:    aws.String(functionName),
+		Name:            aws.String(aliasName),
+		FunctionVersion: aws.String(prevVersion),
+		RoutingConfig:   &lambdav2types.AliasRoutingConfiguration{},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update alias: %w", err)
+	}
+	return nil
+}
+
+// isVersionAliased reports whether any alias of functionName still points at version.
+func (app *App) isVersionAliased(ctx context.Context, functionName, version string) (bool, error) {
+	aliased, err := app.aliasedVersions(ctx, functionName)
+	if err != nil {
+		return false, err
+	}
+	return aliased[version], nil
+}
@@ -0,0 +1,83 @@
+package lambroll
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindPreviousVersionUsing(t *testing.T) {
+	cases := []struct {
+		name    string
+		from    int64
+		steps   int
+		exists  map[int64]bool
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "single step to the immediately preceding version",
+			from:   5,
+			steps:  1,
+			exists: map[int64]bool{4: true, 3: true, 2: true, 1: true},
+			want:   "4",
+		},
+		{
+			name:   "single step skips gaps from deleted versions",
+			from:   5,
+			steps:  1,
+			exists: map[int64]bool{3: true, 2: true, 1: true}, // 4 missing
+			want:   "3",
+		},
+		{
+			name:   "multiple steps count only existing versions",
+			from:   10,
+			steps:  3,
+			exists: map[int64]bool{9: true, 8: true, 6: true, 5: true, 4: true}, // 7 missing
+			want:   "6",
+		},
+		{
+			name:    "runs out of versions before satisfying steps",
+			from:    3,
+			steps:   5,
+			exists:  map[int64]bool{2: true, 1: true},
+			wantErr: true,
+		},
+		{
+			name:    "no previous version at all",
+			from:    1,
+			steps:   1,
+			exists:  map[int64]bool{},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := findPreviousVersionUsing(c.from, c.steps, func(v int64) (bool, error) {
+				return c.exists[v], nil
+			})
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got version %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("findPreviousVersionUsing() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestFindPreviousVersionUsingPropagatesLookupError(t *testing.T) {
+	sentinel := errors.New("boom")
+	_, err := findPreviousVersionUsing(5, 1, func(v int64) (bool, error) {
+		return false, sentinel
+	})
+	if !errors.Is(err, sentinel) {
+		t.Fatalf("expected sentinel error, got %v", err)
+	}
+}
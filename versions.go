@@ -0,0 +1,181 @@
+package lambroll
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdav2 "github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdav2types "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// VersionInfo represents a single published version of a function, annotated
+// with the aliases (if any) currently pointing at it.
+type VersionInfo struct {
+	Version      string   `json:"Version"`
+	LastModified string   `json:"LastModified"`
+	CodeSha256   string   `json:"CodeSha256"`
+	Description  string   `json:"Description,omitempty"`
+	Aliases      []string `json:"Aliases,omitempty"`
+	Runtime      string   `json:"Runtime,omitempty"`
+}
+
+// VersionsOption represents option for Versions()
+type VersionsOption struct {
+	FunctionFilePath *string
+	Output           *string
+	Limit            *int
+}
+
+func (opt VersionsOption) output() string {
+	if opt.Output != nil {
+		return *opt.Output
+	}
+	return "table"
+}
+
+// Versions lists the published versions of a function, newest first, along
+// with the aliases currently pointing at each, so users can see what they'd
+// roll back to before running Rollback.
+func (app *App) Versions(opt VersionsOption) ([]VersionInfo, error) {
+	ctx := context.TODO()
+	fn, err := app.loadFunctionV2(*opt.FunctionFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load function: %w", err)
+	}
+	functionName := *fn.FunctionName
+
+	versions, err := app.listFunctionVersions(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+	aliasesByVersion, err := app.aliasesByVersion(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := buildVersionInfos(versions, aliasesByVersion, opt.Limit)
+
+	switch opt.output() {
+	case "json":
+		return infos, printVersionsJSON(infos)
+	case "tsv":
+		return infos, printVersionsTSV(infos)
+	default:
+		return infos, printVersionsTable(infos)
+	}
+}
+
+// buildVersionInfos sorts versions numerically, builds a VersionInfo per
+// entry annotated with its aliases, orders the result newest-first, and
+// applies limit.
+func buildVersionInfos(versions []lambdav2types.FunctionConfiguration, aliasesByVersion map[string][]string, limit *int) []VersionInfo {
+	sorted := sortVersionsNumeric(versions)
+
+	infos := make([]VersionInfo, 0, len(sorted))
+	for _, v := range sorted {
+		infos = append(infos, VersionInfo{
+			Version:      aws.ToString(v.Version),
+			LastModified: aws.ToString(v.LastModified),
+			CodeSha256:   aws.ToString(v.CodeSha256),
+			Description:  aws.ToString(v.Description),
+			Aliases:      aliasesByVersion[aws.ToString(v.Version)],
+			Runtime:      string(v.Runtime),
+		})
+	}
+	// newest first
+	for i, j := 0, len(infos)-1; i < j; i, j = i+1, j-1 {
+		infos[i], infos[j] = infos[j], infos[i]
+	}
+
+	if limit != nil && *limit > 0 && *limit < len(infos) {
+		infos = infos[:*limit]
+	}
+	return infos
+}
+
+// sortVersionsNumeric returns a copy of versions sorted ascending by their
+// numeric version. Lambda's ListVersionsByFunction page order is not a
+// contract lambroll can rely on for "most recent N" logic, so callers that
+// need numeric order (Prune's KeepVersions, Versions' newest-first listing)
+// sort explicitly instead of trusting API response order.
+func sortVersionsNumeric(versions []lambdav2types.FunctionConfiguration) []lambdav2types.FunctionConfiguration {
+	sorted := make([]lambdav2types.FunctionConfiguration, len(versions))
+	copy(sorted, versions)
+	sort.Slice(sorted, func(i, j int) bool {
+		vi, erri := strconv.ParseInt(aws.ToString(sorted[i].Version), 10, 64)
+		vj, errj := strconv.ParseInt(aws.ToString(sorted[j].Version), 10, 64)
+		if erri != nil || errj != nil {
+			return aws.ToString(sorted[i].Version) < aws.ToString(sorted[j].Version)
+		}
+		return vi < vj
+	})
+	return sorted
+}
+
+// aliasesByVersion returns, for functionName, a map from version to the
+// names of the aliases currently pointing at it.
+func (app *App) aliasesByVersion(ctx context.Context, functionName string) (map[string][]string, error) {
+	byVersion := map[string][]string{}
+	p := lambdav2.NewListAliasesPaginator(app.lambdav2, &lambdav2.ListAliasesInput{
+		FunctionName: aws.String(functionName),
+	})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list aliases: %w", err)
+		}
+		for _, a := range page.Aliases {
+			if a.FunctionVersion == nil || a.Name == nil {
+				continue
+			}
+			byVersion[*a.FunctionVersion] = append(byVersion[*a.FunctionVersion], *a.Name)
+		}
+	}
+	return byVersion, nil
+}
+
+func printVersionsTable(infos []VersionInfo) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tLAST_MODIFIED\tCODE_SHA256\tDESCRIPTION\tALIASES\tRUNTIME")
+	for _, info := range infos {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			info.Version,
+			info.LastModified,
+			info.CodeSha256,
+			info.Description,
+			strings.Join(info.Aliases, ","),
+			info.Runtime,
+		)
+	}
+	return w.Flush()
+}
+
+func printVersionsTSV(infos []VersionInfo) error {
+	for _, info := range infos {
+		fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			info.Version,
+			info.LastModified,
+			info.CodeSha256,
+			info.Description,
+			strings.Join(info.Aliases, ","),
+			info.Runtime,
+		)
+	}
+	return nil
+}
+
+func printVersionsJSON(infos []VersionInfo) error {
+	b, err := json.MarshalIndent(infos, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal versions: %w", err)
+	}
+	fmt.Fprintln(os.Stdout, string(b))
+	return nil
+}
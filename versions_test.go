@@ -0,0 +1,51 @@
+package lambroll
+
+import (
+	"reflect"
+	"testing"
+
+	lambdav2types "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+func TestBuildVersionInfos(t *testing.T) {
+	// deliberately out of numeric order and not page-sorted.
+	versions := []lambdav2types.FunctionConfiguration{
+		fnConfig("10", "2026-07-20T00:00:00Z"),
+		fnConfig("2", "2026-07-10T00:00:00Z"),
+		fnConfig("9", "2026-07-19T00:00:00Z"),
+	}
+	aliasesByVersion := map[string][]string{
+		"2": {"current"},
+	}
+
+	infos := buildVersionInfos(versions, aliasesByVersion, nil)
+
+	gotVersions := make([]string, len(infos))
+	for i, info := range infos {
+		gotVersions[i] = info.Version
+	}
+	want := []string{"10", "9", "2"} // newest (highest numeric) first
+	if !reflect.DeepEqual(gotVersions, want) {
+		t.Fatalf("buildVersionInfos() versions = %v, want %v", gotVersions, want)
+	}
+	if !reflect.DeepEqual(infos[2].Aliases, []string{"current"}) {
+		t.Errorf("expected version 2 to carry its alias, got %v", infos[2].Aliases)
+	}
+}
+
+func TestBuildVersionInfosLimit(t *testing.T) {
+	versions := []lambdav2types.FunctionConfiguration{
+		fnConfig("1", "2026-07-10T00:00:00Z"),
+		fnConfig("2", "2026-07-11T00:00:00Z"),
+		fnConfig("3", "2026-07-12T00:00:00Z"),
+	}
+
+	limit := 2
+	infos := buildVersionInfos(versions, nil, &limit)
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 infos, got %d", len(infos))
+	}
+	if infos[0].Version != "3" || infos[1].Version != "2" {
+		t.Errorf("expected newest-first truncation [3 2], got [%s %s]", infos[0].Version, infos[1].Version)
+	}
+}